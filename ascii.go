@@ -0,0 +1,136 @@
+package gostl
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WriteAsciiToFile writes a Model to an ASCII STL file
+func (m *Model) WriteAsciiToFile(filepath string) error {
+	writer, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	w := bufio.NewWriter(writer)
+
+	if _, err := fmt.Fprintf(w, "solid %s\n", solidName(filepath)); err != nil {
+		return err
+	}
+	for _, t := range m.Triangles {
+		if _, err := fmt.Fprintf(w, "facet normal %s\n", formatVertex(t.Normal)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, "outer loop\n"); err != nil {
+			return err
+		}
+		for _, p := range [3][3]float32{t.P0, t.P1, t.P2} {
+			if _, err := fmt.Fprintf(w, "vertex %s\n", formatVertex(p)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(w, "endloop\nendfacet\n"); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "endsolid %s\n", solidName(filepath)); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func solidName(filepath string) string {
+	base := filepath
+	if i := strings.LastIndexAny(base, "/\\"); i >= 0 {
+		base = base[i+1:]
+	}
+	return strings.TrimSuffix(base, ".stl")
+}
+
+func formatVertex(v [3]float32) string {
+	return fmt.Sprintf("%e %e %e", v[0], v[1], v[2])
+}
+
+// ParseAsciiStlFile parses an ASCII STL file into a Model
+func ParseAsciiStlFile(filepath string) (*Model, error) {
+	reader, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var Triangles []Triangle
+	var current Triangle
+	vertexIndex := 0
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "facet":
+			if len(fields) != 5 || fields[1] != "normal" {
+				return nil, fmt.Errorf("could not parse ascii stl file, malformed facet normal line: %q", scanner.Text())
+			}
+			n, err := parseVertex(fields[2:])
+			if err != nil {
+				return nil, err
+			}
+			current = Triangle{Normal: n}
+		case "vertex":
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("could not parse ascii stl file, malformed vertex line: %q", scanner.Text())
+			}
+			p, err := parseVertex(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			switch vertexIndex {
+			case 0:
+				current.P0 = p
+			case 1:
+				current.P1 = p
+			case 2:
+				current.P2 = p
+			default:
+				return nil, fmt.Errorf("could not parse ascii stl file, too many vertices in facet")
+			}
+			vertexIndex++
+		case "endfacet":
+			if vertexIndex != 3 {
+				return nil, fmt.Errorf("could not parse ascii stl file, facet had %d vertices, expected 3", vertexIndex)
+			}
+			Triangles = append(Triangles, current)
+			current = Triangle{}
+			vertexIndex = 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	m := Model{filepath, Triangles}
+	return &m, nil
+}
+
+func parseVertex(fields []string) (v [3]float32, err error) {
+	if len(fields) != 3 {
+		return v, fmt.Errorf("expected 3 coordinates, got %d", len(fields))
+	}
+	for i, f := range fields {
+		x, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return v, fmt.Errorf("could not parse coordinate %q: %v", f, err)
+		}
+		v[i] = float32(x)
+	}
+	return v, nil
+}
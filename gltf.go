@@ -0,0 +1,34 @@
+package gostl
+
+import "github.com/quells/gostl/gltf"
+
+// WriteGLTF writes a Model as a glTF 2.0 asset: a single mesh with one
+// TRIANGLES primitive, with its binary buffer embedded as a base64 data
+// URI. Normals are broadcast per-vertex from each Triangle's face normal.
+func (m *Model) WriteGLTF(filepath string) error {
+	return m.toDocument().WriteGLTF(filepath)
+}
+
+// WriteGLB writes a Model as a binary .glb glTF 2.0 asset.
+func (m *Model) WriteGLB(filepath string) error {
+	return m.toDocument().WriteGLB(filepath)
+}
+
+func (m *Model) toDocument() *gltf.Document {
+	positions := make([]float32, 0, 9*len(m.Triangles))
+	normals := make([]float32, 0, 9*len(m.Triangles))
+	indices := make([]uint32, 0, 3*len(m.Triangles))
+
+	var index uint32
+	for _, t := range m.Triangles {
+		for _, p := range [3][3]float32{t.P0, t.P1, t.P2} {
+			positions = append(positions, p[0], p[1], p[2])
+			normals = append(normals, t.Normal[0], t.Normal[1], t.Normal[2])
+			indices = append(indices, index)
+			index++
+		}
+	}
+
+	min, max := m.BoundingBox()
+	return gltf.NewTriangleDocument(positions, normals, indices, min, max)
+}
@@ -0,0 +1,22 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+func float32Bytes(xs []float32) []byte {
+	buf := make([]byte, 4*len(xs))
+	for i, x := range xs {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf
+}
+
+func uint32Bytes(xs []uint32) []byte {
+	buf := make([]byte, 4*len(xs))
+	for i, x := range xs {
+		binary.LittleEndian.PutUint32(buf[4*i:], x)
+	}
+	return buf
+}
@@ -0,0 +1,161 @@
+// Package gltf builds minimal glTF 2.0 documents: a single mesh with a
+// single TRIANGLES primitive and POSITION/NORMAL/INDICES accessors backed
+// by one binary buffer holding each accessor's data in its own consecutive,
+// non-interleaved buffer view.
+package gltf
+
+const (
+	componentTypeUnsignedInt = 5125
+	componentTypeFloat       = 5126
+
+	modeTriangles = 4
+)
+
+// Asset is the required glTF asset descriptor
+type Asset struct {
+	Version string `json:"version"`
+}
+
+// Buffer describes a block of binary data, either by URI (.gltf) or
+// implicitly the single chunk of a .glb
+type Buffer struct {
+	URI        string `json:"uri,omitempty"`
+	ByteLength int    `json:"byteLength"`
+}
+
+// BufferView describes a contiguous, 4-byte-aligned slice of a Buffer
+type BufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+}
+
+// Accessor describes how to interpret a BufferView as typed components
+type Accessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float32 `json:"min,omitempty"`
+	Max           []float32 `json:"max,omitempty"`
+}
+
+// Primitive is a single drawable piece of a Mesh
+type Primitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Mode       int            `json:"mode"`
+}
+
+// Mesh is a collection of Primitives
+type Mesh struct {
+	Primitives []Primitive `json:"primitives"`
+}
+
+// Node references a Mesh to place it in the default scene
+type Node struct {
+	Mesh int `json:"mesh"`
+}
+
+// Scene lists the root Nodes to render
+type Scene struct {
+	Nodes []int `json:"nodes"`
+}
+
+// Document is the top-level glTF 2.0 JSON structure
+type Document struct {
+	Asset       Asset        `json:"asset"`
+	Scene       int          `json:"scene"`
+	Scenes      []Scene      `json:"scenes"`
+	Nodes       []Node       `json:"nodes"`
+	Meshes      []Mesh       `json:"meshes"`
+	Accessors   []Accessor   `json:"accessors"`
+	BufferViews []BufferView `json:"bufferViews"`
+	Buffers     []Buffer     `json:"buffers"`
+
+	binary []byte
+}
+
+// NewTriangleDocument builds a Document for a single indexed triangle
+// primitive: positions and normals are VEC3/FLOAT, indices are
+// SCALAR/UINT32. min/max are the POSITION accessor bounds.
+func NewTriangleDocument(positions, normals []float32, indices []uint32, min, max [3]float32) *Document {
+	var buf []byte
+
+	positionBytes := float32Bytes(positions)
+	positionView := appendView(&buf, positionBytes)
+
+	normalBytes := float32Bytes(normals)
+	normalView := appendView(&buf, normalBytes)
+
+	indexBytes := uint32Bytes(indices)
+	indexView := appendView(&buf, indexBytes)
+
+	doc := &Document{
+		Asset: Asset{Version: "2.0"},
+		Scene: 0,
+		Scenes: []Scene{
+			{Nodes: []int{0}},
+		},
+		Nodes: []Node{
+			{Mesh: 0},
+		},
+		Meshes: []Mesh{
+			{
+				Primitives: []Primitive{
+					{
+						Attributes: map[string]int{
+							"POSITION": 0,
+							"NORMAL":   1,
+						},
+						Indices: 2,
+						Mode:    modeTriangles,
+					},
+				},
+			},
+		},
+		Accessors: []Accessor{
+			{
+				BufferView:    0,
+				ComponentType: componentTypeFloat,
+				Count:         len(positions) / 3,
+				Type:          "VEC3",
+				Min:           min[:],
+				Max:           max[:],
+			},
+			{
+				BufferView:    1,
+				ComponentType: componentTypeFloat,
+				Count:         len(normals) / 3,
+				Type:          "VEC3",
+			},
+			{
+				BufferView:    2,
+				ComponentType: componentTypeUnsignedInt,
+				Count:         len(indices),
+				Type:          "SCALAR",
+			},
+		},
+		BufferViews: []BufferView{positionView, normalView, indexView},
+		Buffers: []Buffer{
+			{ByteLength: len(buf)},
+		},
+		binary: buf,
+	}
+	return doc
+}
+
+// appendView appends data to buf padded to a 4-byte boundary with zeros,
+// and returns the BufferView describing the unpadded data.
+func appendView(buf *[]byte, data []byte) BufferView {
+	offset := len(*buf)
+	*buf = append(*buf, data...)
+	if pad := (4 - len(*buf)%4) % 4; pad != 0 {
+		*buf = append(*buf, make([]byte, pad)...)
+	}
+	return BufferView{
+		Buffer:     0,
+		ByteOffset: offset,
+		ByteLength: len(data),
+	}
+}
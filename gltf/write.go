@@ -0,0 +1,96 @@
+package gltf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	glbMagic         = 0x46546C67 // "glTF"
+	glbVersion       = 2
+	glbHeaderSize    = 12
+	glbChunkHeader   = 8
+	glbChunkTypeJSON = 0x4E4F534A // "JSON"
+	glbChunkTypeBIN  = 0x004E4942 // "BIN\0"
+)
+
+// WriteGLTF writes the Document as a .gltf file with its binary buffer
+// embedded as a base64 data URI.
+func (d *Document) WriteGLTF(filepath string) error {
+	embedded := *d
+	embedded.Buffers = append([]Buffer{}, d.Buffers...)
+	embedded.Buffers[0].URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(d.binary)
+
+	data, err := json.Marshal(embedded)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath, data, 0644)
+}
+
+// WriteGLB writes the Document as a single binary .glb file: a 12-byte
+// header followed by a JSON chunk and a BIN chunk.
+func (d *Document) WriteGLB(filepath string) error {
+	jsonChunk, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	jsonChunk = padChunk(jsonChunk, ' ')
+
+	binChunk := padChunk(d.binary, 0)
+
+	total := glbHeaderSize + glbChunkHeader + len(jsonChunk) + glbChunkHeader + len(binChunk)
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, uint32(glbMagic)); err != nil {
+		return err
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, uint32(glbVersion)); err != nil {
+		return err
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, uint32(total)); err != nil {
+		return err
+	}
+
+	if err := writeChunk(&buf, glbChunkTypeJSON, jsonChunk); err != nil {
+		return err
+	}
+	if err := writeChunk(&buf, glbChunkTypeBIN, binChunk); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath, buf.Bytes(), 0644)
+}
+
+func writeChunk(buf *bytes.Buffer, chunkType uint32, data []byte) error {
+	if err := binary.Write(buf, binary.LittleEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	if err := binary.Write(buf, binary.LittleEndian, chunkType); err != nil {
+		return err
+	}
+	n, err := buf.Write(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return fmt.Errorf("could not write glb chunk, unexpected length %d, expected %d", n, len(data))
+	}
+	return nil
+}
+
+func padChunk(data []byte, pad byte) []byte {
+	if rem := len(data) % 4; rem != 0 {
+		padding := make([]byte, 4-rem)
+		for i := range padding {
+			padding[i] = pad
+		}
+		data = append(data, padding...)
+	}
+	return data
+}
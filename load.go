@@ -0,0 +1,61 @@
+package gostl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// Load reads an STL file of either encoding, sniffing the header to decide
+// whether to dispatch to ParseStlFile or ParseAsciiStlFile.
+//
+// Some binary STL files are mislabeled with a "solid" prefix in their
+// 80-byte header, so the "solid" keyword alone is not sufficient: Load also
+// checks whether the file size matches what the binary triangle-count
+// header implies, and only treats the file as ASCII if it does not.
+func Load(filepath string) (*Model, error) {
+	isAscii, err := isAsciiStlFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	if isAscii {
+		return ParseAsciiStlFile(filepath)
+	}
+	return ParseStlFile(filepath)
+}
+
+func isAsciiStlFile(filepath string) (bool, error) {
+	info, err := os.Stat(filepath)
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.Open(filepath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	head := make([]byte, stlHeaderSize+stlCountSize)
+	n, err := io.ReadFull(file, head)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Too short to be a well-formed binary file; fall back to
+		// whatever the "solid" sniff says.
+		return bytes.HasPrefix(bytes.TrimSpace(head[:n]), []byte("solid")), nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if info.Size() == expectedBinarySize(head) {
+		return false, nil
+	}
+
+	return bytes.HasPrefix(head, []byte("solid")), nil
+}
+
+func expectedBinarySize(head []byte) int64 {
+	count := binary.LittleEndian.Uint32(head[stlHeaderSize : stlHeaderSize+stlCountSize])
+	return int64(stlHeaderSize) + int64(stlCountSize) + int64(count)*int64(stlTriangleSize)
+}
@@ -0,0 +1,103 @@
+package gostl
+
+import "math"
+
+// Mesh is an indexed triangle mesh (a "TIN"): vertices are stored once and
+// referenced by index, instead of the one-vertex-per-corner duplication
+// that a Model's flat Triangle list carries.
+type Mesh struct {
+	Vertices [][3]float32
+	Indices  [][3]uint32
+	Normals  [][3]float32
+}
+
+// Edge is an undirected pair of vertex indices, always stored with the
+// smaller index first so it can key a map regardless of winding direction.
+type Edge [2]uint32
+
+// ToMesh welds a Model's duplicated Triangle corners into a shared vertex
+// table, using a spatial hash keyed on coordinates quantized to epsilon.
+func (m *Model) ToMesh(epsilon float32) *Mesh {
+	vertexIndex := make(map[[3]int32]uint32)
+	var vertices [][3]float32
+	var indices [][3]uint32
+	var normals [][3]float32
+
+	weld := func(p [3]float32) uint32 {
+		key := quantize(p, epsilon)
+		if idx, ok := vertexIndex[key]; ok {
+			return idx
+		}
+		idx := uint32(len(vertices))
+		vertices = append(vertices, p)
+		vertexIndex[key] = idx
+		return idx
+	}
+
+	for _, t := range m.Triangles {
+		indices = append(indices, [3]uint32{weld(t.P0), weld(t.P1), weld(t.P2)})
+		normals = append(normals, t.Normal)
+	}
+
+	return &Mesh{Vertices: vertices, Indices: indices, Normals: normals}
+}
+
+func quantize(p [3]float32, epsilon float32) [3]int32 {
+	return [3]int32{
+		int32(math.Round(float64(p[0] / epsilon))),
+		int32(math.Round(float64(p[1] / epsilon))),
+		int32(math.Round(float64(p[2] / epsilon))),
+	}
+}
+
+// ToModel expands a Mesh back into a Model with one Triangle per face,
+// duplicating shared vertices as needed.
+func (msh *Mesh) ToModel() *Model {
+	Triangles := make([]Triangle, len(msh.Indices))
+	for i, tri := range msh.Indices {
+		Triangles[i] = Triangle{
+			Normal: msh.Normals[i],
+			P0:     msh.Vertices[tri[0]],
+			P1:     msh.Vertices[tri[1]],
+			P2:     msh.Vertices[tri[2]],
+		}
+	}
+	return &Model{Triangles: Triangles}
+}
+
+// VertexTriangles maps each vertex index to the indices of the Triangles
+// incident to it.
+func (msh *Mesh) VertexTriangles() map[uint32][]uint32 {
+	adjacency := make(map[uint32][]uint32, len(msh.Vertices))
+	for ti, tri := range msh.Indices {
+		for _, v := range tri {
+			adjacency[v] = append(adjacency[v], uint32(ti))
+		}
+	}
+	return adjacency
+}
+
+// EdgeTriangles maps each Edge to the indices of the Triangles that share
+// it, so that a well-formed watertight mesh has exactly two Triangles per
+// Edge and a boundary or non-manifold Edge has one or more than two.
+func (msh *Mesh) EdgeTriangles() map[Edge][]uint32 {
+	adjacency := make(map[Edge][]uint32)
+	for ti, tri := range msh.Indices {
+		edges := [3]Edge{
+			edgeOf(tri[0], tri[1]),
+			edgeOf(tri[1], tri[2]),
+			edgeOf(tri[2], tri[0]),
+		}
+		for _, e := range edges {
+			adjacency[e] = append(adjacency[e], uint32(ti))
+		}
+	}
+	return adjacency
+}
+
+func edgeOf(a, b uint32) Edge {
+	if a < b {
+		return Edge{a, b}
+	}
+	return Edge{b, a}
+}
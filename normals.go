@@ -0,0 +1,137 @@
+package gostl
+
+import "fmt"
+
+// NormalStrategy selects how (*Model).RecomputeNormals derives each
+// Triangle's Normal.
+type NormalStrategy int
+
+const (
+	// FaceCCW sets each Triangle's Normal to the right-hand-rule cross
+	// product of its edges, (P1-P0) x (P2-P0), ignoring whatever Normal
+	// was previously stored.
+	FaceCCW NormalStrategy = iota
+
+	// FaceOutward computes FaceCCW normals, then flips any face whose
+	// normal points toward the interior of the mesh rather than away
+	// from it. Requires a closed, manifold surface.
+	FaceOutward
+
+	// Smooth sets each Triangle's Normal to the area-weighted average of
+	// its corners' incident face normals, using the vertex welding done
+	// by (*Model).ToMesh.
+	Smooth
+)
+
+const normalWeldEpsilon float32 = 1e-5
+
+// RecomputeNormals replaces every Triangle's Normal according to
+// strategy.
+func (m *Model) RecomputeNormals(strategy NormalStrategy) error {
+	switch strategy {
+	case FaceCCW:
+		m.recomputeFaceCCW()
+	case FaceOutward:
+		m.recomputeFaceOutward()
+	case Smooth:
+		m.recomputeSmooth()
+	default:
+		return fmt.Errorf("gostl: unknown NormalStrategy %d", strategy)
+	}
+	return nil
+}
+
+func (m *Model) recomputeFaceCCW() {
+	for i, t := range m.Triangles {
+		m.Triangles[i].Normal = normalize(cross(sub(t.P1, t.P0), sub(t.P2, t.P0)))
+	}
+}
+
+func (m *Model) recomputeFaceOutward() {
+	m.recomputeFaceCCW()
+
+	min, max := m.BoundingBox()
+	nudge := length(sub(max, min)) * 1e-4
+
+	for i, t := range m.Triangles {
+		probe := add(centroid(t.P0, t.P1, t.P2), scale(t.Normal, nudge))
+		if m.contains(probe) {
+			m.Triangles[i].Normal = scale(t.Normal, -1)
+		}
+	}
+}
+
+// contains reports whether p is inside the Model's surface by firing a
+// ray in a fixed, non-axis-aligned direction and counting crossings: an
+// odd count means p is inside. Assumes a closed, manifold surface.
+func (m *Model) contains(p [3]float32) bool {
+	dir := [3]float32{1, 0.0137, 0.0271}
+	crossings := 0
+	for _, t := range m.Triangles {
+		if _, hit := rayTriangleIntersect(p, dir, t.P0, t.P1, t.P2); hit {
+			crossings++
+		}
+	}
+	return crossings%2 == 1
+}
+
+const rayEpsilon = 1e-7
+
+// rayTriangleIntersect is the Möller–Trumbore ray-triangle intersection
+// test. It returns the distance along dir to the hit point.
+func rayTriangleIntersect(origin, dir, p0, p1, p2 [3]float32) (float32, bool) {
+	edge1 := sub(p1, p0)
+	edge2 := sub(p2, p0)
+
+	h := cross(dir, edge2)
+	a := dot(edge1, h)
+	if a > -rayEpsilon && a < rayEpsilon {
+		return 0, false
+	}
+
+	f := 1 / a
+	s := sub(origin, p0)
+	u := f * dot(s, h)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+
+	q := cross(s, edge1)
+	v := f * dot(dir, q)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+
+	t := f * dot(edge2, q)
+	if t <= rayEpsilon {
+		return 0, false
+	}
+	return t, true
+}
+
+func (m *Model) recomputeSmooth() {
+	mesh := m.ToMesh(normalWeldEpsilon)
+
+	faceNormals := make([][3]float32, len(mesh.Indices))
+	faceAreas := make([]float32, len(mesh.Indices))
+	for i, tri := range mesh.Indices {
+		p0, p1, p2 := mesh.Vertices[tri[0]], mesh.Vertices[tri[1]], mesh.Vertices[tri[2]]
+		n := cross(sub(p1, p0), sub(p2, p0))
+		faceAreas[i] = length(n) / 2
+		faceNormals[i] = normalize(n)
+	}
+
+	vertexNormals := make([][3]float32, len(mesh.Vertices))
+	for v, incident := range mesh.VertexTriangles() {
+		var sum [3]float32
+		for _, ti := range incident {
+			sum = add(sum, scale(faceNormals[ti], faceAreas[ti]))
+		}
+		vertexNormals[v] = normalize(sum)
+	}
+
+	for i, tri := range mesh.Indices {
+		avg := add(add(vertexNormals[tri[0]], vertexNormals[tri[1]]), vertexNormals[tri[2]])
+		m.Triangles[i].Normal = normalize(avg)
+	}
+}
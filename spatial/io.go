@@ -0,0 +1,175 @@
+package spatial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// localCRS is the EPSG-style coordinate reference tag used for Octrees
+// built from Model coordinates with no associated CRS.
+const localCRS = 0
+
+// Serialize writes the Octree to w: an uncompressed header (CRS tag,
+// vertex/triangle/node counts, and the length of the body that follows),
+// then the vertex, normal, triangle, triangle-order, and node arrays,
+// snappy-compressed as a single block.
+//
+// Named Serialize rather than WriteTo so as not to imply the io.WriterTo
+// signature, which this does not satisfy.
+func (o *Octree) Serialize(w io.Writer) error {
+	var body bytes.Buffer
+
+	for _, v := range o.vertices {
+		if err := binary.Write(&body, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	for _, n := range o.normals {
+		if err := binary.Write(&body, binary.LittleEndian, n); err != nil {
+			return err
+		}
+	}
+	for _, tri := range o.tris {
+		if err := binary.Write(&body, binary.LittleEndian, tri); err != nil {
+			return err
+		}
+	}
+	if err := binary.Write(&body, binary.LittleEndian, o.triangleOrder); err != nil {
+		return err
+	}
+	for _, n := range o.nodes {
+		if err := writeNode(&body, n); err != nil {
+			return err
+		}
+	}
+
+	compressed := snappy.Encode(nil, body.Bytes())
+
+	header := struct {
+		CRSTag        int32
+		VertexCount   uint32
+		TriangleCount uint32
+		NodeCount     uint32
+		BodyLength    uint32
+	}{
+		CRSTag:        localCRS,
+		VertexCount:   uint32(len(o.vertices)),
+		TriangleCount: uint32(len(o.tris)),
+		NodeCount:     uint32(len(o.nodes)),
+		BodyLength:    uint32(len(compressed)),
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	n, err := w.Write(compressed)
+	if err != nil {
+		return err
+	}
+	if n != len(compressed) {
+		return fmt.Errorf("could not write octree body, wrote %d of %d bytes", n, len(compressed))
+	}
+	return nil
+}
+
+func writeNode(w io.Writer, n node) error {
+	if err := binary.Write(w, binary.LittleEndian, n.min); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, n.max); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, n.children); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, n.start); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, n.count)
+}
+
+// ReadOctree reads an Octree previously written by (*Octree).Serialize.
+func ReadOctree(r io.Reader) (*Octree, error) {
+	var header struct {
+		CRSTag        int32
+		VertexCount   uint32
+		TriangleCount uint32
+		NodeCount     uint32
+		BodyLength    uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.CRSTag != localCRS {
+		return nil, fmt.Errorf("unsupported octree CRS tag %d", header.CRSTag)
+	}
+
+	compressed := make([]byte, header.BodyLength)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, err
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, err
+	}
+	br := bytes.NewReader(body)
+
+	o := &Octree{
+		vertices: make([][3]float32, header.VertexCount),
+		normals:  make([][3]float32, header.TriangleCount),
+		tris:     make([][3]int32, header.TriangleCount),
+	}
+
+	for i := range o.vertices {
+		if err := binary.Read(br, binary.LittleEndian, &o.vertices[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range o.normals {
+		if err := binary.Read(br, binary.LittleEndian, &o.normals[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range o.tris {
+		if err := binary.Read(br, binary.LittleEndian, &o.tris[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	o.triangleOrder = make([]int32, header.TriangleCount)
+	if err := binary.Read(br, binary.LittleEndian, &o.triangleOrder); err != nil {
+		return nil, err
+	}
+
+	o.nodes = make([]node, header.NodeCount)
+	for i := range o.nodes {
+		n, err := readNode(br)
+		if err != nil {
+			return nil, err
+		}
+		o.nodes[i] = n
+	}
+
+	return o, nil
+}
+
+func readNode(r io.Reader) (n node, err error) {
+	if err = binary.Read(r, binary.LittleEndian, &n.min); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.LittleEndian, &n.max); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.LittleEndian, &n.children); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.LittleEndian, &n.start); err != nil {
+		return
+	}
+	err = binary.Read(r, binary.LittleEndian, &n.count)
+	return
+}
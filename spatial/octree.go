@@ -0,0 +1,225 @@
+// Package spatial builds an octree spatial index over a gostl.Model for
+// fast ray, containment, and nearest-triangle queries, with a compact
+// serialization format for reuse across runs.
+package spatial
+
+import gostl "github.com/quells/gostl"
+
+// node is an octree node. Internal nodes have one or more non-negative
+// children; leaf nodes have all children set to -1 and reference a
+// contiguous run of triangleOrder via start/count.
+type node struct {
+	min, max     [3]float32
+	children     [8]int32
+	start, count int32
+}
+
+// Octree spatially indexes the Triangles of a Model. Triangles are stored
+// as an indexed triangle list (vertices + vertex-index triples) rather
+// than a flat Triangle slice; each Triangle's three corners are appended
+// as their own vertex entries, so this does not weld shared vertices the
+// way (*Model).ToMesh does.
+type Octree struct {
+	vertices [][3]float32
+	normals  [][3]float32
+	tris     [][3]int32
+
+	nodes         []node
+	triangleOrder []int32
+}
+
+const noChild = int32(-1)
+
+// BuildOctree partitions a Model's Triangles by their axis-aligned
+// bounding box centroid, recursing up to maxDepth or until a node holds
+// leafCapacity or fewer Triangles.
+func BuildOctree(m *gostl.Model, maxDepth, leafCapacity int) *Octree {
+	triangles := m.Triangles
+
+	vertices := make([][3]float32, 0, 3*len(triangles))
+	normals := make([][3]float32, len(triangles))
+	tris := make([][3]int32, len(triangles))
+	centroids := make([][3]float32, len(triangles))
+	for i, t := range triangles {
+		i0 := int32(len(vertices))
+		vertices = append(vertices, t.P0, t.P1, t.P2)
+		tris[i] = [3]int32{i0, i0 + 1, i0 + 2}
+		normals[i] = t.Normal
+		centroids[i] = centroid(t.P0, t.P1, t.P2)
+	}
+
+	o := &Octree{
+		vertices: vertices,
+		normals:  normals,
+		tris:     tris,
+	}
+
+	order := make([]int32, len(triangles))
+	for i := range order {
+		order[i] = int32(i)
+	}
+
+	subMin, subMax := m.BoundingBox()
+	o.build(subMin, subMax, order, 0, len(order), 0, maxDepth, leafCapacity)
+	o.triangleOrder = order
+
+	return o
+}
+
+// build recursively partitions order[start:start+count] in place so that
+// every node's range, including leaves, indexes its own contiguous run of
+// the shared order slice rather than always starting at 0. subMin/subMax
+// is the octant subdivision region used to route triangles by centroid;
+// it is routinely smaller than the triangles it ends up holding, so the
+// node's own min/max is instead set to the tight union AABB of the
+// Triangles (leaves) or child nodes (internal nodes) it actually holds,
+// which is what queries prune against. build appends nodes to o.nodes and
+// returns the index of the node created for this call.
+func (o *Octree) build(subMin, subMax [3]float32, order []int32, start, count, depth, maxDepth, leafCapacity int) int32 {
+	idx := int32(len(o.nodes))
+	o.nodes = append(o.nodes, node{children: [8]int32{noChild, noChild, noChild, noChild, noChild, noChild, noChild, noChild}})
+
+	if depth >= maxDepth || count <= leafCapacity {
+		o.nodes[idx].min, o.nodes[idx].max = o.triangleBounds(order[start : start+count])
+		o.nodes[idx].start = int32(start)
+		o.nodes[idx].count = int32(count)
+		return idx
+	}
+
+	mid := [3]float32{
+		(subMin[0] + subMax[0]) / 2,
+		(subMin[1] + subMax[1]) / 2,
+		(subMin[2] + subMax[2]) / 2,
+	}
+
+	var buckets [8][]int32
+	for _, ti := range order[start : start+count] {
+		c := o.centroidOf(ti)
+		octant := octantOf(c, mid)
+		buckets[octant] = append(buckets[octant], ti)
+	}
+
+	// A degenerate split (everything landed in one octant) would recurse
+	// forever; fall back to a leaf.
+	nonEmpty := 0
+	for _, b := range buckets {
+		if len(b) > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty <= 1 {
+		o.nodes[idx].min, o.nodes[idx].max = o.triangleBounds(order[start : start+count])
+		o.nodes[idx].start = int32(start)
+		o.nodes[idx].count = int32(count)
+		return idx
+	}
+
+	cursor := start
+	var tightMin, tightMax [3]float32
+	haveBounds := false
+	for octant, b := range buckets {
+		if len(b) == 0 {
+			continue
+		}
+		copy(order[cursor:], b)
+		childStart, childCount := cursor, len(b)
+		cursor += len(b)
+
+		childSubMin, childSubMax := octantBounds(subMin, subMax, mid, octant)
+		child := o.build(childSubMin, childSubMax, order, childStart, childCount, depth+1, maxDepth, leafCapacity)
+		o.nodes[idx].children[octant] = child
+
+		childMin, childMax := o.nodes[child].min, o.nodes[child].max
+		if !haveBounds {
+			tightMin, tightMax = childMin, childMax
+			haveBounds = true
+			continue
+		}
+		tightMin, tightMax = expandBounds(tightMin, tightMax, childMin, childMax)
+	}
+	o.nodes[idx].min, o.nodes[idx].max = tightMin, tightMax
+
+	return idx
+}
+
+// triangleBounds returns the union AABB of the vertices of the Triangles
+// referenced by tis.
+func (o *Octree) triangleBounds(tis []int32) (min, max [3]float32) {
+	haveBounds := false
+	for _, ti := range tis {
+		for _, vi := range o.tris[ti] {
+			v := o.vertices[vi]
+			if !haveBounds {
+				min, max = v, v
+				haveBounds = true
+				continue
+			}
+			min, max = expandBounds(min, max, v, v)
+		}
+	}
+	return
+}
+
+func expandBounds(min, max, pointMin, pointMax [3]float32) ([3]float32, [3]float32) {
+	for axis := 0; axis < 3; axis++ {
+		if pointMin[axis] < min[axis] {
+			min[axis] = pointMin[axis]
+		}
+		if pointMax[axis] > max[axis] {
+			max[axis] = pointMax[axis]
+		}
+	}
+	return min, max
+}
+
+// centroidOf returns the center of the axis-aligned bounding box of the
+// Triangle at ti.
+func (o *Octree) centroidOf(ti int32) [3]float32 {
+	min, max := o.triangleBounds([]int32{ti})
+	return scale(add(min, max), 0.5)
+}
+
+func centroid(a, b, c [3]float32) [3]float32 {
+	return [3]float32{
+		(a[0] + b[0] + c[0]) / 3,
+		(a[1] + b[1] + c[1]) / 3,
+		(a[2] + b[2] + c[2]) / 3,
+	}
+}
+
+func octantOf(p, mid [3]float32) int {
+	octant := 0
+	if p[0] >= mid[0] {
+		octant |= 1
+	}
+	if p[1] >= mid[1] {
+		octant |= 2
+	}
+	if p[2] >= mid[2] {
+		octant |= 4
+	}
+	return octant
+}
+
+func octantBounds(min, max, mid [3]float32, octant int) (childMin, childMax [3]float32) {
+	for axis, bit := range [3]int{1, 2, 4} {
+		if octant&bit != 0 {
+			childMin[axis] = mid[axis]
+			childMax[axis] = max[axis]
+		} else {
+			childMin[axis] = min[axis]
+			childMax[axis] = mid[axis]
+		}
+	}
+	return
+}
+
+func (o *Octree) triangleAt(ti int32) gostl.Triangle {
+	tri := o.tris[ti]
+	return gostl.Triangle{
+		Normal: o.normals[ti],
+		P0:     o.vertices[tri[0]],
+		P1:     o.vertices[tri[1]],
+		P2:     o.vertices[tri[2]],
+	}
+}
@@ -0,0 +1,219 @@
+package spatial
+
+import (
+	"math"
+
+	gostl "github.com/quells/gostl"
+)
+
+const rayEpsilon = 1e-7
+
+// RayIntersect finds the closest Triangle the ray from origin along dir
+// hits, using the Möller–Trumbore algorithm on candidate leaves found by
+// descending nodes whose box the ray crosses.
+func (o *Octree) RayIntersect(origin, dir [3]float32) (gostl.Triangle, float32, bool) {
+	if len(o.nodes) == 0 {
+		return gostl.Triangle{}, 0, false
+	}
+
+	var (
+		best    float32
+		bestTri int32 = -1
+	)
+
+	var visit func(idx int32)
+	visit = func(idx int32) {
+		n := &o.nodes[idx]
+		if !rayBoxIntersect(origin, dir, n.min, n.max) {
+			return
+		}
+
+		if n.count > 0 {
+			for _, ti := range o.triangleOrder[n.start : n.start+n.count] {
+				tri := o.tris[ti]
+				if t, hit := rayTriangleIntersect(origin, dir, o.vertices[tri[0]], o.vertices[tri[1]], o.vertices[tri[2]]); hit {
+					if bestTri == -1 || t < best {
+						best = t
+						bestTri = ti
+					}
+				}
+			}
+			return
+		}
+
+		for _, child := range n.children {
+			if child != noChild {
+				visit(child)
+			}
+		}
+	}
+	visit(0)
+
+	if bestTri == -1 {
+		return gostl.Triangle{}, 0, false
+	}
+	return o.triangleAt(bestTri), best, true
+}
+
+// rayTriangleIntersect is the Möller–Trumbore ray-triangle intersection
+// test. It returns the distance along dir to the hit point.
+func rayTriangleIntersect(origin, dir, p0, p1, p2 [3]float32) (float32, bool) {
+	edge1 := sub(p1, p0)
+	edge2 := sub(p2, p0)
+
+	h := cross(dir, edge2)
+	a := dot(edge1, h)
+	if a > -rayEpsilon && a < rayEpsilon {
+		return 0, false
+	}
+
+	f := 1 / a
+	s := sub(origin, p0)
+	u := f * dot(s, h)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+
+	q := cross(s, edge1)
+	v := f * dot(dir, q)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+
+	t := f * dot(edge2, q)
+	if t <= rayEpsilon {
+		return 0, false
+	}
+	return t, true
+}
+
+// Contains reports whether p is inside the watertight surface described
+// by the Octree, by firing a ray in a fixed direction and counting
+// crossings: an odd number of crossings means p is inside.
+func (o *Octree) Contains(p [3]float32) bool {
+	dir := [3]float32{1, 0.0137, 0.0271} // an arbitrary non-axis-aligned direction avoids most edge/vertex grazes
+	crossings := 0
+
+	var visit func(idx int32)
+	visit = func(idx int32) {
+		n := &o.nodes[idx]
+		if !rayBoxIntersect(p, dir, n.min, n.max) {
+			return
+		}
+
+		if n.count > 0 {
+			for _, ti := range o.triangleOrder[n.start : n.start+n.count] {
+				tri := o.tris[ti]
+				if _, hit := rayTriangleIntersect(p, dir, o.vertices[tri[0]], o.vertices[tri[1]], o.vertices[tri[2]]); hit {
+					crossings++
+				}
+			}
+			return
+		}
+
+		for _, child := range n.children {
+			if child != noChild {
+				visit(child)
+			}
+		}
+	}
+	if len(o.nodes) > 0 {
+		visit(0)
+	}
+
+	return crossings%2 == 1
+}
+
+// Nearest finds the Triangle closest to p, pruning subtrees whose box is
+// already farther away than the best distance found so far.
+func (o *Octree) Nearest(p [3]float32) (gostl.Triangle, float32) {
+	best := float32(math.Inf(1))
+	var bestTri int32 = -1
+
+	var visit func(idx int32)
+	visit = func(idx int32) {
+		n := &o.nodes[idx]
+		if boxDistance(p, n.min, n.max) > best {
+			return
+		}
+
+		if n.count > 0 {
+			for _, ti := range o.triangleOrder[n.start : n.start+n.count] {
+				tri := o.tris[ti]
+				d := pointTriangleDistance(p, o.vertices[tri[0]], o.vertices[tri[1]], o.vertices[tri[2]])
+				if bestTri == -1 || d < best {
+					best = d
+					bestTri = ti
+				}
+			}
+			return
+		}
+
+		for _, child := range n.children {
+			if child != noChild {
+				visit(child)
+			}
+		}
+	}
+	if len(o.nodes) > 0 {
+		visit(0)
+	}
+
+	if bestTri == -1 {
+		return gostl.Triangle{}, 0
+	}
+	return o.triangleAt(bestTri), best
+}
+
+// pointTriangleDistance returns the distance from p to the closest point
+// on triangle (a, b, c), clamping the projection of p onto the triangle's
+// plane into the triangle itself.
+func pointTriangleDistance(p, a, b, c [3]float32) float32 {
+	ab := sub(b, a)
+	ac := sub(c, a)
+	ap := sub(p, a)
+
+	d1 := dot(ab, ap)
+	d2 := dot(ac, ap)
+	if d1 <= 0 && d2 <= 0 {
+		return length(sub(p, a))
+	}
+
+	bp := sub(p, b)
+	d3 := dot(ab, bp)
+	d4 := dot(ac, bp)
+	if d3 >= 0 && d4 <= d3 {
+		return length(sub(p, b))
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		v := d1 / (d1 - d3)
+		return length(sub(p, add(a, scale(ab, v))))
+	}
+
+	cp := sub(p, c)
+	d5 := dot(ab, cp)
+	d6 := dot(ac, cp)
+	if d6 >= 0 && d5 <= d6 {
+		return length(sub(p, c))
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		w := d2 / (d2 - d6)
+		return length(sub(p, add(a, scale(ac, w))))
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		w := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return length(sub(p, add(b, scale(sub(c, b), w))))
+	}
+
+	denom := 1 / (va + vb + vc)
+	v := vb * denom
+	w := vc * denom
+	closest := add(a, add(scale(ab, v), scale(ac, w)))
+	return length(sub(p, closest))
+}
@@ -0,0 +1,50 @@
+package spatial
+
+import "math"
+
+// rayBoxIntersect reports whether the ray from origin along dir crosses
+// the axis-aligned box [min, max], using the slab method.
+func rayBoxIntersect(origin, dir, min, max [3]float32) bool {
+	tmin := float32(math.Inf(-1))
+	tmax := float32(math.Inf(1))
+
+	for axis := 0; axis < 3; axis++ {
+		if dir[axis] == 0 {
+			if origin[axis] < min[axis] || origin[axis] > max[axis] {
+				return false
+			}
+			continue
+		}
+		invD := 1 / dir[axis]
+		t0 := (min[axis] - origin[axis]) * invD
+		t1 := (max[axis] - origin[axis]) * invD
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tmin {
+			tmin = t0
+		}
+		if t1 < tmax {
+			tmax = t1
+		}
+		if tmax < tmin {
+			return false
+		}
+	}
+
+	return tmax >= 0
+}
+
+// boxDistance returns the distance from p to the nearest point of the
+// axis-aligned box [min, max], zero if p is inside.
+func boxDistance(p, min, max [3]float32) float32 {
+	d := [3]float32{}
+	for axis := 0; axis < 3; axis++ {
+		if p[axis] < min[axis] {
+			d[axis] = min[axis] - p[axis]
+		} else if p[axis] > max[axis] {
+			d[axis] = p[axis] - max[axis]
+		}
+	}
+	return length(d)
+}
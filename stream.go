@@ -0,0 +1,149 @@
+package gostl
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// TriangleReader streams Triangles out of a binary STL file one record at a
+// time, decoding each stlTriangleSize-byte record on demand instead of
+// loading the whole file into memory.
+type TriangleReader struct {
+	file    *os.File
+	r       *bufio.Reader
+	count   uint32
+	read    uint32
+	scratch [stlTriangleSize]byte
+}
+
+// Open opens a binary STL file for streaming reads
+func Open(filepath string) (*TriangleReader, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(file)
+
+	header := make([]byte, stlHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	countBytes := make([]byte, stlCountSize)
+	if _, err := io.ReadFull(r, countBytes); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &TriangleReader{
+		file:  file,
+		r:     r,
+		count: binary.LittleEndian.Uint32(countBytes),
+	}, nil
+}
+
+// Count returns the number of Triangles declared in the file header
+func (tr *TriangleReader) Count() uint32 {
+	return tr.count
+}
+
+// Next decodes and returns the next Triangle, returning io.EOF once Count
+// Triangles have been read
+func (tr *TriangleReader) Next() (Triangle, error) {
+	if tr.read >= tr.count {
+		return Triangle{}, io.EOF
+	}
+	if _, err := io.ReadFull(tr.r, tr.scratch[:]); err != nil {
+		return Triangle{}, err
+	}
+	tr.read++
+	return triangleFromBytes(&tr.scratch), nil
+}
+
+// Close closes the underlying file
+func (tr *TriangleReader) Close() error {
+	return tr.file.Close()
+}
+
+// TriangleWriter streams Triangles into a binary STL file one record at a
+// time.
+type TriangleWriter struct {
+	file    *os.File
+	w       *bufio.Writer
+	scratch [stlTriangleSize]byte
+}
+
+// Create creates a binary STL file, writing its header and declared
+// Triangle count upfront. The caller must write exactly count Triangles.
+func Create(filepath string, count uint32) (*TriangleWriter, error) {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(file)
+
+	header := make([]byte, stlHeaderSize)
+	copy(header, []byte("STL"))
+	if _, err := w.Write(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	countBytes := make([]byte, stlCountSize)
+	binary.LittleEndian.PutUint32(countBytes, count)
+	if _, err := w.Write(countBytes); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &TriangleWriter{file: file, w: w}, nil
+}
+
+// Write encodes and writes a single Triangle
+func (tw *TriangleWriter) Write(t Triangle) error {
+	bytesFromTriangle(t, &tw.scratch)
+	n, err := tw.w.Write(tw.scratch[:])
+	if err != nil {
+		return err
+	}
+	if n < stlTriangleSize {
+		return fmt.Errorf("could not write Triangle")
+	}
+	return nil
+}
+
+// Close flushes any buffered writes and closes the underlying file
+func (tw *TriangleWriter) Close() error {
+	if err := tw.w.Flush(); err != nil {
+		tw.file.Close()
+		return err
+	}
+	return tw.file.Close()
+}
+
+func triangleFromBytes(buffer *[stlTriangleSize]byte) Triangle {
+	ps := [4][3]float32{}
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 3; i++ {
+			offset := i*stlFloatSize + j*3*stlFloatSize
+			ui := binary.LittleEndian.Uint32(buffer[offset : offset+4])
+			ps[j][i] = math.Float32frombits(ui)
+		}
+	}
+	return Triangle{ps[0], ps[1], ps[2], ps[3]}
+}
+
+func bytesFromTriangle(t Triangle, buffer *[stlTriangleSize]byte) {
+	ps := [4][3]float32{t.Normal, t.P0, t.P1, t.P2}
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 3; i++ {
+			offset := i*stlFloatSize + j*3*stlFloatSize
+			binary.LittleEndian.PutUint32(buffer[offset:offset+4], math.Float32bits(ps[j][i]))
+		}
+	}
+}
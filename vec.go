@@ -0,0 +1,43 @@
+package gostl
+
+import "math"
+
+func add(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func sub(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func scale(a [3]float32, s float32) [3]float32 {
+	return [3]float32{a[0] * s, a[1] * s, a[2] * s}
+}
+
+func dot(a, b [3]float32) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b [3]float32) [3]float32 {
+	return [3]float32{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func length(a [3]float32) float32 {
+	return float32(math.Sqrt(float64(dot(a, a))))
+}
+
+func normalize(a [3]float32) [3]float32 {
+	l := length(a)
+	if l == 0 {
+		return a
+	}
+	return scale(a, 1/l)
+}
+
+func centroid(a, b, c [3]float32) [3]float32 {
+	return scale(add(add(a, b), c), 1.0/3)
+}
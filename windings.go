@@ -0,0 +1,80 @@
+package gostl
+
+// FixWindings propagates a single consistent winding direction across the
+// Model's Triangles. Starting from an arbitrary Triangle in each connected
+// component of the welded mesh, it walks the edge-adjacency graph
+// breadth-first, flipping any neighbor whose shared edge runs in the same
+// direction as its already-visited neighbor's (a well-wound pair shares an
+// edge running in opposite directions). STL files assembled from multiple
+// sources or repaired by hand frequently have a handful of flipped faces,
+// which this corrects without needing the Normals to be trustworthy.
+func (m *Model) FixWindings() {
+	mesh := m.ToMesh(normalWeldEpsilon)
+
+	adjacency := make([][]int, len(mesh.Indices))
+	for _, tris := range mesh.EdgeTriangles() {
+		if len(tris) != 2 {
+			// Boundary or non-manifold edge; nothing to propagate across.
+			continue
+		}
+		a, b := int(tris[0]), int(tris[1])
+		adjacency[a] = append(adjacency[a], b)
+		adjacency[b] = append(adjacency[b], a)
+	}
+
+	visited := make([]bool, len(mesh.Indices))
+	for start := range mesh.Indices {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		queue := []int{start}
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			for _, nb := range adjacency[cur] {
+				if visited[nb] {
+					continue
+				}
+				visited[nb] = true
+				if sharedEdgeSameDirection(mesh.Indices[cur], mesh.Indices[nb]) {
+					flipTriangle(mesh, nb)
+				}
+				queue = append(queue, nb)
+			}
+		}
+	}
+
+	*m = *mesh.ToModel()
+}
+
+func flipTriangle(mesh *Mesh, i int) {
+	tri := mesh.Indices[i]
+	mesh.Indices[i] = [3]uint32{tri[0], tri[2], tri[1]}
+	mesh.Normals[i] = scale(mesh.Normals[i], -1)
+}
+
+// sharedEdgeSameDirection reports whether the edge shared between a and b
+// is directed the same way in both windings, which means they are wound
+// inconsistently relative to each other.
+func sharedEdgeSameDirection(a, b [3]uint32) bool {
+	for _, e1 := range directedEdges(a) {
+		for _, e2 := range directedEdges(b) {
+			if e1 == e2 {
+				return true
+			}
+			if e1 == [2]uint32{e2[1], e2[0]} {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+func directedEdges(tri [3]uint32) [3][2]uint32 {
+	return [3][2]uint32{
+		{tri[0], tri[1]},
+		{tri[1], tri[2]},
+		{tri[2], tri[0]},
+	}
+}